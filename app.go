@@ -7,8 +7,10 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/fsnotify/fsnotify"
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
@@ -16,14 +18,27 @@ type App struct {
 	ctx            context.Context
 	currentProject string
 	recentFiles    []string
+
+	watchMu      sync.Mutex
+	watcher      *fsnotify.Watcher
+	watchPath    string
+	watchDone    chan struct{}
+	pendingMu    sync.Mutex
+	pendingPaths map[string]string
+	debounce     *time.Timer
+
+	processMu  sync.Mutex
+	processes  map[string]*runningProcess
+	processSeq int
 }
 
 type FileInfo struct {
-	Name     string `json:"name"`
-	Path     string `json:"path"`
-	IsDir    bool   `json:"isDir"`
-	Size     int64  `json:"size"`
-	Modified string `json:"modified"`
+	Name     string     `json:"name"`
+	Path     string     `json:"path"`
+	IsDir    bool       `json:"isDir"`
+	Size     int64      `json:"size"`
+	Modified string     `json:"modified"`
+	Children []FileInfo `json:"children,omitempty"`
 }
 
 func NewApp() *App {
@@ -75,34 +90,24 @@ func (a *App) CheckFilePermissions(filePath string) error {
 }
 
 func (a *App) WriteFile(filePath, content string) error {
-	// Check permissions first
-	if err := a.CheckFilePermissions(filePath); err != nil {
-		return err
-	}
 	dir := filepath.Dir(filePath)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return fmt.Errorf("failed to create directory %s: %v", dir, err)
 	}
-	
+
 	// Check if file exists and get its permissions
 	var perm os.FileMode = 0644
 	if info, err := os.Stat(filePath); err == nil {
 		perm = info.Mode().Perm()
 	}
-	
-	// Write to temporary file first to avoid data loss
-	tempFile := filePath + ".tmp"
-	if err := ioutil.WriteFile(tempFile, []byte(content), perm); err != nil {
-		return fmt.Errorf("failed to write temporary file: %v", err)
-	}
-	
-	// Atomic move from temp to final location
-	if err := os.Rename(tempFile, filePath); err != nil {
-		os.Remove(tempFile) // Clean up temp file on error
-		return fmt.Errorf("failed to save file: %v", err)
-	}
-	
-	return nil
+
+	return InWritableDir(dir, func() error {
+		// Check permissions first, now that dir is guaranteed writable
+		if err := a.CheckFilePermissions(filePath); err != nil {
+			return err
+		}
+		return atomicWriteFile(filePath, []byte(content), perm)
+	})
 }
 
 func (a *App) CreateFile(filePath string) error {
@@ -126,10 +131,6 @@ func (a *App) CreateFolder(folderPath string) error {
 	return os.MkdirAll(folderPath, 0755)
 }
 
-func (a *App) DeleteFile(filePath string) error {
-	return os.RemoveAll(filePath)
-}
-
 func (a *App) ListFiles(dirPath string) ([]FileInfo, error) {
 	if dirPath == "" {
 		dirPath = "."
@@ -166,6 +167,7 @@ func (a *App) GetCurrentProject() string {
 }
 
 func (a *App) SetCurrentProject(projectPath string) {
+	a.killAllProcesses()
 	a.currentProject = projectPath
 }
 
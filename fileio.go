@@ -0,0 +1,117 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"syscall"
+)
+
+// atomicWriteFile writes content to filePath by writing to a temp file in
+// the same directory, fsyncing it, and renaming it into place. The temp
+// file is always a sibling of filePath, so the rename is always on the same
+// filesystem and can't fail with EXDEV — that cross-device case is handled
+// separately by isCrossDeviceError/copyAcrossDevices, which moveFile (in
+// trash.go) uses for moves between genuinely different directories (e.g.
+// project file to .sonet-trash).
+func atomicWriteFile(filePath string, content []byte, perm os.FileMode) error {
+	tempFile := filePath + ".tmp"
+
+	f, err := os.OpenFile(tempFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %v", err)
+	}
+
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		os.Remove(tempFile)
+		return fmt.Errorf("failed to write temporary file: %v", err)
+	}
+
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(tempFile)
+		return fmt.Errorf("failed to sync temporary file: %v", err)
+	}
+
+	if err := f.Close(); err != nil {
+		os.Remove(tempFile)
+		return fmt.Errorf("failed to close temporary file: %v", err)
+	}
+
+	if err := os.Rename(tempFile, filePath); err != nil {
+		os.Remove(tempFile)
+		return fmt.Errorf("failed to save file: %v", err)
+	}
+
+	return nil
+}
+
+// isCrossDeviceError reports whether err is a rename failure caused by the
+// source and destination living on different filesystems (EXDEV), which
+// os.Rename cannot handle itself.
+func isCrossDeviceError(err error) bool {
+	var linkErr *os.LinkError
+	if errors.As(err, &linkErr) {
+		return errors.Is(linkErr.Err, syscall.EXDEV)
+	}
+	return false
+}
+
+// copyAcrossDevices copies src to dst, fsyncs the destination, and removes
+// src. It's the fallback for renames that fail with EXDEV.
+func copyAcrossDevices(src, dst string, perm os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+
+	return os.Remove(src)
+}
+
+// InWritableDir runs fn with dir guaranteed to be writable, temporarily
+// adding the write bit if the directory lacks it and restoring the original
+// mode afterward. Restore failures are logged rather than returned, since by
+// that point fn has already run and the caller's result shouldn't be masked.
+func InWritableDir(dir string, fn func() error) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+
+	origMode := info.Mode().Perm()
+	if origMode&0200 != 0 {
+		return fn()
+	}
+
+	if err := os.Chmod(dir, origMode|0200); err != nil {
+		return fmt.Errorf("failed to make %s writable: %v", dir, err)
+	}
+	defer func() {
+		if err := os.Chmod(dir, origMode); err != nil {
+			log.Printf("failed to restore permissions on %s: %v", dir, err)
+		}
+	}()
+
+	return fn()
+}
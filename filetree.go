@@ -0,0 +1,172 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ListOptions controls how ListFilesRecursive walks a directory tree.
+type ListOptions struct {
+	MaxDepth         int      `json:"maxDepth"`         // 0 means unlimited
+	ExcludeGlobs     []string `json:"excludeGlobs"`     // filepath.Match patterns, matched against the entry name
+	RespectGitignore bool     `json:"respectGitignore"` // honor the .gitignore in the root directory (nested ones are not read)
+}
+
+// ListFilesRecursive walks dirPath and returns the directory tree as nested
+// FileInfo entries, with each directory's contents in its Children field.
+// Entries matching opts.ExcludeGlobs, or ignored via .gitignore when
+// opts.RespectGitignore is set, are omitted. Permission errors on individual
+// entries are skipped rather than aborting the walk; they are joined into
+// the returned error so the caller can surface them without losing the
+// partial tree.
+func (a *App) ListFilesRecursive(dirPath string, opts ListOptions) ([]FileInfo, error) {
+	if dirPath == "" {
+		dirPath = "."
+	}
+
+	root, err := filepath.Abs(dirPath)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &treeWalker{
+		opts:    opts,
+		visited: make(map[string]bool),
+	}
+	w.loadGitignore(root)
+
+	children, err := w.walk(root, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return children, errors.Join(w.skipped...)
+}
+
+type treeWalker struct {
+	opts    ListOptions
+	visited map[string]bool // resolved real paths already visited, guards symlink loops
+	ignore  []string        // gitignore patterns collected from root
+	skipped []error
+}
+
+func (w *treeWalker) walk(dirPath string, depth int) ([]FileInfo, error) {
+	if w.opts.MaxDepth > 0 && depth > w.opts.MaxDepth {
+		return nil, nil
+	}
+
+	entries, err := os.ReadDir(dirPath)
+	if err != nil {
+		if os.IsPermission(err) {
+			w.skipped = append(w.skipped, err)
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var out []FileInfo
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+		if w.isExcluded(name) {
+			continue
+		}
+
+		entryPath := filepath.Join(dirPath, name)
+
+		info, err := entry.Info()
+		if err != nil {
+			if os.IsPermission(err) {
+				w.skipped = append(w.skipped, err)
+				continue
+			}
+			return nil, err
+		}
+
+		isDir := entry.IsDir()
+		if info.Mode()&os.ModeSymlink != 0 {
+			resolved, err := filepath.EvalSymlinks(entryPath)
+			if err != nil {
+				if os.IsPermission(err) {
+					w.skipped = append(w.skipped, err)
+					continue
+				}
+				return nil, err
+			}
+			if w.visited[resolved] {
+				continue // symlink loop
+			}
+			target, err := os.Stat(resolved)
+			if err != nil {
+				if os.IsPermission(err) {
+					w.skipped = append(w.skipped, err)
+					continue
+				}
+				return nil, err
+			}
+			w.visited[resolved] = true
+			isDir = target.IsDir()
+		}
+
+		fi := FileInfo{
+			Name:     name,
+			Path:     entryPath,
+			IsDir:    isDir,
+			Size:     info.Size(),
+			Modified: info.ModTime().Format(time.RFC3339),
+		}
+
+		if isDir {
+			children, err := w.walk(entryPath, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			fi.Children = children
+		}
+
+		out = append(out, fi)
+	}
+
+	return out, nil
+}
+
+func (w *treeWalker) isExcluded(name string) bool {
+	for _, pattern := range w.opts.ExcludeGlobs {
+		if matched, _ := filepath.Match(pattern, name); matched {
+			return true
+		}
+	}
+	if w.opts.RespectGitignore {
+		for _, pattern := range w.ignore {
+			if matched, _ := filepath.Match(pattern, name); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// loadGitignore reads a .gitignore in root, if present, keeping simple
+// name/glob patterns (one per line, '#' comments and blank lines skipped).
+// Nested .gitignore files and negation patterns are not supported.
+func (w *treeWalker) loadGitignore(root string) {
+	if !w.opts.RespectGitignore {
+		return
+	}
+	data, err := os.ReadFile(filepath.Join(root, ".gitignore"))
+	if err != nil {
+		return
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		w.ignore = append(w.ignore, strings.TrimSuffix(line, "/"))
+	}
+}
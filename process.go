@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ptySession abstracts a PTY-backed child process so StartProcess doesn't
+// need to care whether it's talking to a Unix pty or a Windows ConPTY. Kill
+// signals the whole process tree to terminate; Wait blocks until the child
+// has actually exited and reaps it.
+type ptySession interface {
+	io.ReadWriteCloser
+	Wait() error
+	Kill() error
+}
+
+// startPTY launches cmd with args in cwd attached to a new pseudo-terminal.
+// It is implemented per-platform in process_unix.go and process_windows.go.
+var startPTY func(cmd string, args []string, cwd string) (ptySession, error)
+
+// ProcessOutput is emitted on "process:output" as a running process writes
+// to its combined stdout/stderr stream.
+type ProcessOutput struct {
+	ProcID string `json:"procId"`
+	Data   string `json:"data"`
+}
+
+// ProcessExit is emitted on "process:exit" once a process terminates.
+type ProcessExit struct {
+	ProcID string `json:"procId"`
+	Error  string `json:"error,omitempty"`
+}
+
+type runningProcess struct {
+	id  string
+	pty ptySession
+}
+
+// StartProcess launches cmd with args in cwd behind a PTY, streaming its
+// combined output to the frontend via "process:output" and returning a
+// procID that WriteStdin and KillProcess use to address it. Processes are
+// tied to the current project: SetCurrentProject kills any still running
+// from the previous one.
+func (a *App) StartProcess(cmd string, args []string, cwd string) (string, error) {
+	if startPTY == nil {
+		return "", fmt.Errorf("pty support is not available on this platform")
+	}
+
+	session, err := startPTY(cmd, args, cwd)
+	if err != nil {
+		return "", fmt.Errorf("failed to start process: %v", err)
+	}
+
+	a.processMu.Lock()
+	a.processSeq++
+	procID := fmt.Sprintf("proc-%d", a.processSeq)
+	if a.processes == nil {
+		a.processes = make(map[string]*runningProcess)
+	}
+	a.processes[procID] = &runningProcess{id: procID, pty: session}
+	a.processMu.Unlock()
+
+	go a.pumpProcessOutput(procID, session)
+
+	return procID, nil
+}
+
+// pumpProcessOutput streams session's combined output to the frontend until
+// the pty closes, then waits for the underlying child to actually exit so
+// it doesn't linger as a zombie.
+func (a *App) pumpProcessOutput(procID string, session ptySession) {
+	reader := bufio.NewReader(session)
+	buf := make([]byte, 4096)
+	for {
+		n, err := reader.Read(buf)
+		if n > 0 && a.ctx != nil {
+			runtime.EventsEmit(a.ctx, "process:output", ProcessOutput{ProcID: procID, Data: string(buf[:n])})
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	waitErr := session.Wait()
+
+	a.processMu.Lock()
+	delete(a.processes, procID)
+	a.processMu.Unlock()
+
+	if a.ctx != nil {
+		exit := ProcessExit{ProcID: procID}
+		if waitErr != nil {
+			exit.Error = waitErr.Error()
+		}
+		runtime.EventsEmit(a.ctx, "process:exit", exit)
+	}
+}
+
+// WriteStdin writes data to the stdin of the process identified by procID.
+func (a *App) WriteStdin(procID string, data string) error {
+	a.processMu.Lock()
+	proc, ok := a.processes[procID]
+	a.processMu.Unlock()
+	if !ok {
+		return fmt.Errorf("no such process: %s", procID)
+	}
+	_, err := proc.pty.Write([]byte(data))
+	return err
+}
+
+// KillProcess terminates the process identified by procID and its
+// descendants. The process is reaped and removed from the registry by
+// pumpProcessOutput once the pty closes.
+func (a *App) KillProcess(procID string) error {
+	a.processMu.Lock()
+	proc, ok := a.processes[procID]
+	a.processMu.Unlock()
+	if !ok {
+		return fmt.Errorf("no such process: %s", procID)
+	}
+	return proc.pty.Kill()
+}
+
+// killAllProcesses terminates every process started for the current
+// project. Called when the project changes so child processes don't
+// outlive the workspace they were launched in.
+func (a *App) killAllProcesses() {
+	a.processMu.Lock()
+	procs := make([]*runningProcess, 0, len(a.processes))
+	for _, proc := range a.processes {
+		procs = append(procs, proc)
+	}
+	a.processMu.Unlock()
+
+	for _, proc := range procs {
+		proc.pty.Kill()
+	}
+}
@@ -0,0 +1,54 @@
+//go:build !windows
+
+package main
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+
+	"github.com/creack/pty"
+)
+
+func init() {
+	startPTY = startPTYUnix
+}
+
+// unixPTY pairs the pty master with the *exec.Cmd that owns the child
+// process, so Kill can signal the whole process group and Wait can reap it.
+type unixPTY struct {
+	master *os.File
+	cmd    *exec.Cmd
+}
+
+func startPTYUnix(cmdName string, args []string, cwd string) (ptySession, error) {
+	c := exec.Command(cmdName, args...)
+	c.Dir = cwd
+	// pty.Start runs the child in a new session, which also makes it the
+	// leader of its own process group, so Kill can signal every descendant
+	// it spawns, not just the immediate child.
+	f, err := pty.Start(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return &unixPTY{master: f, cmd: c}, nil
+}
+
+func (u *unixPTY) Read(p []byte) (int, error)  { return u.master.Read(p) }
+func (u *unixPTY) Write(p []byte) (int, error) { return u.master.Write(p) }
+func (u *unixPTY) Close() error                { return u.master.Close() }
+
+// Wait blocks until the child has exited and reaps it.
+func (u *unixPTY) Wait() error {
+	return u.cmd.Wait()
+}
+
+// Kill sends SIGKILL to the child's entire process group.
+func (u *unixPTY) Kill() error {
+	pgid, err := syscall.Getpgid(u.cmd.Process.Pid)
+	if err != nil {
+		return u.cmd.Process.Kill()
+	}
+	return syscall.Kill(-pgid, syscall.SIGKILL)
+}
@@ -0,0 +1,45 @@
+//go:build windows
+
+package main
+
+import (
+	"context"
+	"strings"
+
+	"github.com/UserExistsError/conpty"
+)
+
+func init() {
+	startPTY = startPTYWindows
+}
+
+// conPTYSession adapts conpty.ConPty to the ptySession shape StartProcess
+// expects.
+type conPTYSession struct {
+	*conpty.ConPty
+}
+
+func startPTYWindows(cmdName string, args []string, cwd string) (ptySession, error) {
+	commandLine := cmdName
+	if len(args) > 0 {
+		commandLine = cmdName + " " + strings.Join(args, " ")
+	}
+
+	cp, err := conpty.Start(commandLine, conpty.ConPtyWorkDir(cwd))
+	if err != nil {
+		return nil, err
+	}
+
+	return &conPTYSession{ConPty: cp}, nil
+}
+
+// Wait blocks until the child has exited and reaps it.
+func (c *conPTYSession) Wait() error {
+	_, err := c.ConPty.Wait(context.Background())
+	return err
+}
+
+// Kill terminates the child process tree.
+func (c *conPTYSession) Kill() error {
+	return c.ConPty.Kill()
+}
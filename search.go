@@ -0,0 +1,262 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// SearchQuery describes a project-wide search request.
+type SearchQuery struct {
+	Query         string   `json:"query"`
+	IsRegex       bool     `json:"isRegex"`
+	CaseSensitive bool     `json:"caseSensitive"`
+	WholeWord     bool     `json:"wholeWord"`
+	IncludeGlobs  []string `json:"includeGlobs"`
+	ExcludeGlobs  []string `json:"excludeGlobs"`
+	MaxResults    int      `json:"maxResults"`
+}
+
+// SearchMatch is a single matching line within a file.
+type SearchMatch struct {
+	Path       string `json:"path"`
+	Line       int    `json:"line"`
+	Column     int    `json:"column"`
+	LineText   string `json:"lineText"`
+	MatchStart int    `json:"matchStart"`
+	MatchEnd   int    `json:"matchEnd"`
+}
+
+// SearchResults is the final, aggregated outcome of SearchProject. Matches
+// are also streamed incrementally via the "search:match" event as they're
+// found, so the frontend doesn't have to wait for the whole project to be
+// scanned before showing anything.
+type SearchResults struct {
+	Matches   []SearchMatch `json:"matches"`
+	Truncated bool          `json:"truncated"`
+}
+
+// ReplaceEdit is a single replacement to apply within a file.
+type ReplaceEdit struct {
+	Path    string `json:"path"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	OldText string `json:"oldText"`
+	NewText string `json:"newText"`
+}
+
+// SearchProject performs a parallel search across the current project,
+// emitting each match to the frontend via "search:match" as it's found and
+// returning the full result set once the scan completes.
+func (a *App) SearchProject(query SearchQuery) (SearchResults, error) {
+	root := a.currentProject
+	if root == "" {
+		root = "."
+	}
+
+	pattern, err := buildSearchPattern(query)
+	if err != nil {
+		return SearchResults{}, fmt.Errorf("invalid search query: %v", err)
+	}
+
+	maxResults := query.MaxResults
+	if maxResults <= 0 {
+		maxResults = 1000
+	}
+
+	var (
+		mu        sync.Mutex
+		matches   []SearchMatch
+		truncated bool
+		wg        sync.WaitGroup
+		sem       = make(chan struct{}, 8)
+	)
+
+	err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		name := info.Name()
+		if info.IsDir() {
+			if strings.HasPrefix(name, ".") && path != root {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasPrefix(name, ".") {
+			return nil
+		}
+		if !matchesGlobs(name, query.IncludeGlobs, true) || matchesGlobs(name, query.ExcludeGlobs, false) {
+			return nil
+		}
+
+		mu.Lock()
+		stop := len(matches) >= maxResults
+		if stop {
+			truncated = true
+		}
+		mu.Unlock()
+		if stop {
+			return nil
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			found := searchFile(path, pattern)
+			if len(found) == 0 {
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			for _, m := range found {
+				if len(matches) >= maxResults {
+					truncated = true
+					break
+				}
+				matches = append(matches, m)
+				if a.ctx != nil {
+					runtime.EventsEmit(a.ctx, "search:match", m)
+				}
+			}
+		}()
+
+		return nil
+	})
+	wg.Wait()
+
+	if err != nil {
+		return SearchResults{}, err
+	}
+
+	return SearchResults{Matches: matches, Truncated: truncated}, nil
+}
+
+// ReplaceInFiles applies edits atomically, rewriting each affected file via
+// the same temp-file+rename pattern WriteFile uses so a reader never
+// observes a partially-edited file.
+func (a *App) ReplaceInFiles(edits []ReplaceEdit) error {
+	byFile := make(map[string][]ReplaceEdit)
+	for _, e := range edits {
+		byFile[e.Path] = append(byFile[e.Path], e)
+	}
+
+	for path, fileEdits := range byFile {
+		if err := applyReplaceEdits(path, fileEdits); err != nil {
+			return fmt.Errorf("failed to apply edits to %s: %v", path, err)
+		}
+	}
+	return nil
+}
+
+func applyReplaceEdits(path string, edits []ReplaceEdit) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	byLine := make(map[int][]ReplaceEdit, len(edits))
+	for _, e := range edits {
+		byLine[e.Line] = append(byLine[e.Line], e)
+	}
+
+	lines := strings.Split(string(content), "\n")
+	for lineNum, lineEdits := range byLine {
+		idx := lineNum - 1
+		if idx < 0 || idx >= len(lines) {
+			continue
+		}
+
+		// Apply right-to-left so each edit's column is still valid when it
+		// is applied: an edit's length change only shifts text to its right,
+		// which has already been edited.
+		sort.Slice(lineEdits, func(i, j int) bool { return lineEdits[i].Column > lineEdits[j].Column })
+
+		line := lines[idx]
+		for _, edit := range lineEdits {
+			if edit.Column > 0 && edit.Column-1+len(edit.OldText) <= len(line) {
+				start := edit.Column - 1
+				line = line[:start] + edit.NewText + line[start+len(edit.OldText):]
+			} else {
+				line = strings.Replace(line, edit.OldText, edit.NewText, 1)
+			}
+		}
+		lines[idx] = line
+	}
+
+	var perm os.FileMode = 0644
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+
+	return atomicWriteFile(path, []byte(strings.Join(lines, "\n")), perm)
+}
+
+func buildSearchPattern(query SearchQuery) (*regexp.Regexp, error) {
+	expr := query.Query
+	if !query.IsRegex {
+		expr = regexp.QuoteMeta(expr)
+	}
+	if query.WholeWord {
+		expr = `\b` + expr + `\b`
+	}
+	if !query.CaseSensitive {
+		expr = "(?i)" + expr
+	}
+	return regexp.Compile(expr)
+}
+
+func searchFile(path string, pattern *regexp.Regexp) []SearchMatch {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var matches []SearchMatch
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		for _, loc := range pattern.FindAllStringIndex(line, -1) {
+			matches = append(matches, SearchMatch{
+				Path:       path,
+				Line:       lineNum,
+				Column:     loc[0] + 1,
+				LineText:   line,
+				MatchStart: loc[0],
+				MatchEnd:   loc[1],
+			})
+		}
+	}
+	return matches
+}
+
+// matchesGlobs reports whether name matches any of the given patterns. When
+// patterns is empty, emptyMatchesAll controls the result — include-glob
+// lists default to "match everything" when unset, exclude-glob lists
+// default to "match nothing".
+func matchesGlobs(name string, patterns []string, emptyMatchesAll bool) bool {
+	if len(patterns) == 0 {
+		return emptyMatchesAll
+	}
+	for _, p := range patterns {
+		if matched, _ := filepath.Match(p, name); matched {
+			return true
+		}
+	}
+	return false
+}
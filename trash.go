@@ -0,0 +1,267 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// maxTrashBytes caps how much space .sonet-trash is allowed to use before
+// the oldest entries are evicted to make room for new ones.
+const maxTrashBytes = 500 * 1024 * 1024 // 500MB
+
+// TrashEntry records where a deleted file came from so it can be restored.
+type TrashEntry struct {
+	ID           string `json:"id"`
+	OriginalPath string `json:"originalPath"`
+	TrashPath    string `json:"trashPath"`
+	Size         int64  `json:"size"`
+	Timestamp    string `json:"timestamp"`
+}
+
+// DeleteFile moves filePath into the project's .sonet-trash directory
+// instead of removing it outright, recording a journal entry so it can be
+// restored with RestoreFromTrash. Trash size is capped at maxTrashBytes,
+// evicting the oldest entries first. A file that alone exceeds the cap is
+// left in place and reported as an error rather than trashed and then
+// immediately evicted.
+func (a *App) DeleteFile(filePath string) error {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(absPath)
+	if err != nil {
+		return err
+	}
+
+	trashDir := a.trashDir(absPath)
+	if err := os.MkdirAll(trashDir, 0755); err != nil {
+		return fmt.Errorf("failed to create trash directory: %v", err)
+	}
+
+	journal, err := readTrashJournal(trashDir)
+	if err != nil {
+		return err
+	}
+
+	id := fmt.Sprintf("%d", time.Now().UnixNano())
+	trashPath := filepath.Join(trashDir, id+"-"+filepath.Base(absPath))
+
+	if err := moveFile(absPath, trashPath); err != nil {
+		return fmt.Errorf("failed to move %s to trash: %v", absPath, err)
+	}
+
+	entry := TrashEntry{
+		ID:           id,
+		OriginalPath: absPath,
+		TrashPath:    trashPath,
+		Size:         dirSize(trashPath, info),
+		Timestamp:    time.Now().Format(time.RFC3339),
+	}
+
+	if entry.Size > maxTrashBytes {
+		// Too big to ever fit the cap alongside anything else; moving it in
+		// would just evict it (and everything else) right back out. Put it
+		// back and tell the caller instead of silently losing the file.
+		if restoreErr := moveFile(trashPath, absPath); restoreErr != nil {
+			return fmt.Errorf("file %s (%d bytes) exceeds the %d byte trash limit and could not be restored after the failed trash attempt: %v", absPath, entry.Size, maxTrashBytes, restoreErr)
+		}
+		return fmt.Errorf("file %s (%d bytes) exceeds the %d byte trash limit; it was not moved to trash", absPath, entry.Size, maxTrashBytes)
+	}
+
+	journal = append(journal, entry)
+	journal = evictOldest(journal, maxTrashBytes, entry.ID)
+
+	return writeTrashJournal(trashDir, journal)
+}
+
+// RestoreFromTrash moves the trash entry identified by id back to its
+// original location, recreating parent directories as needed.
+func (a *App) RestoreFromTrash(id string) error {
+	trashDir := a.trashDir("")
+	journal, err := readTrashJournal(trashDir)
+	if err != nil {
+		return err
+	}
+
+	idx := -1
+	for i, e := range journal {
+		if e.ID == id {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		return fmt.Errorf("no trash entry with id %s", id)
+	}
+
+	entry := journal[idx]
+	if err := os.MkdirAll(filepath.Dir(entry.OriginalPath), 0755); err != nil {
+		return fmt.Errorf("failed to recreate %s: %v", filepath.Dir(entry.OriginalPath), err)
+	}
+	if err := moveFile(entry.TrashPath, entry.OriginalPath); err != nil {
+		return fmt.Errorf("failed to restore %s: %v", entry.OriginalPath, err)
+	}
+
+	journal = append(journal[:idx], journal[idx+1:]...)
+	return writeTrashJournal(trashDir, journal)
+}
+
+// EmptyTrash permanently deletes everything currently in the trash.
+func (a *App) EmptyTrash() error {
+	trashDir := a.trashDir("")
+	journal, err := readTrashJournal(trashDir)
+	if err != nil {
+		return err
+	}
+	for _, e := range journal {
+		os.RemoveAll(e.TrashPath)
+	}
+	return writeTrashJournal(trashDir, nil)
+}
+
+// ListTrash returns all current trash entries, most recently deleted first.
+func (a *App) ListTrash() ([]TrashEntry, error) {
+	journal, err := readTrashJournal(a.trashDir(""))
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(journal, func(i, j int) bool {
+		return journal[i].Timestamp > journal[j].Timestamp
+	})
+	return journal, nil
+}
+
+// trashDir returns the .sonet-trash directory for the current project. If
+// no project is set, it falls back to a .sonet-trash alongside forPath.
+func (a *App) trashDir(forPath string) string {
+	if a.currentProject != "" {
+		return filepath.Join(a.currentProject, ".sonet-trash")
+	}
+	return filepath.Join(filepath.Dir(forPath), ".sonet-trash")
+}
+
+func readTrashJournal(trashDir string) ([]TrashEntry, error) {
+	data, err := os.ReadFile(filepath.Join(trashDir, "journal.json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var journal []TrashEntry
+	if err := json.Unmarshal(data, &journal); err != nil {
+		return nil, fmt.Errorf("corrupt trash journal: %v", err)
+	}
+	return journal, nil
+}
+
+func writeTrashJournal(trashDir string, journal []TrashEntry) error {
+	data, err := json.MarshalIndent(journal, "", "  ")
+	if err != nil {
+		return err
+	}
+	return atomicWriteFile(filepath.Join(trashDir, "journal.json"), data, 0644)
+}
+
+// evictOldest removes the oldest entries (and their on-disk trash copies)
+// until the journal's total size is within limit. protectID is never
+// evicted, even if the journal can't be brought under limit without it —
+// callers are expected to have already verified protectID's entry fits
+// within limit on its own.
+func evictOldest(journal []TrashEntry, limit int64, protectID string) []TrashEntry {
+	var total int64
+	for _, e := range journal {
+		total += e.Size
+	}
+
+	sorted := append([]TrashEntry(nil), journal...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Timestamp < sorted[j].Timestamp })
+
+	evicted := make(map[string]bool)
+	for _, e := range sorted {
+		if total <= limit {
+			break
+		}
+		if e.ID == protectID {
+			continue
+		}
+		os.RemoveAll(e.TrashPath)
+		evicted[e.ID] = true
+		total -= e.Size
+	}
+
+	if len(evicted) == 0 {
+		return journal
+	}
+	kept := make([]TrashEntry, 0, len(journal))
+	for _, e := range journal {
+		if !evicted[e.ID] {
+			kept = append(kept, e)
+		}
+	}
+	return kept
+}
+
+// moveFile moves src to dst, falling back to a recursive copy+remove when
+// the rename fails with EXDEV (src and dst on different filesystems).
+func moveFile(src, dst string) error {
+	if err := os.Rename(src, dst); err != nil {
+		if !isCrossDeviceError(err) {
+			return err
+		}
+		if err := copyTree(src, dst); err != nil {
+			return err
+		}
+		return os.RemoveAll(src)
+	}
+	return nil
+}
+
+// copyTree recursively copies src to dst, preserving directory structure.
+func copyTree(src, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+
+	if !info.IsDir() {
+		return copyAcrossDevices(src, dst, info.Mode().Perm())
+	}
+
+	if err := os.MkdirAll(dst, info.Mode().Perm()); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := copyTree(filepath.Join(src, entry.Name()), filepath.Join(dst, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dirSize returns the total size of path, which may be a file or a
+// directory. info is the os.Stat result already obtained for path before
+// it moved, used directly when path is a plain file.
+func dirSize(path string, info os.FileInfo) int64 {
+	if !info.IsDir() {
+		return info.Size()
+	}
+	var total int64
+	filepath.Walk(path, func(_ string, fi os.FileInfo, err error) error {
+		if err == nil && !fi.IsDir() {
+			total += fi.Size()
+		}
+		return nil
+	})
+	return total
+}
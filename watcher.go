@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// debounceWindow controls how long we wait after the last fs event (on any
+// path) before notifying the frontend once per changed path.
+const debounceWindow = 250 * time.Millisecond
+
+// FileChangeEvent is emitted to the frontend over the "file:changed" event
+// whenever StartWatching detects activity under the watched directory.
+type FileChangeEvent struct {
+	Path string `json:"path"`
+	Op   string `json:"op"`
+}
+
+// StartWatching begins recursively watching path for file system changes,
+// emitting debounced "file:changed" events to the frontend. Calling it again
+// replaces any existing watch.
+func (a *App) StartWatching(path string) error {
+	if err := a.StopWatching(); err != nil {
+		return err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create watcher: %v", err)
+	}
+
+	if err := addWatchDirs(watcher, path); err != nil {
+		watcher.Close()
+		return fmt.Errorf("failed to watch %s: %v", path, err)
+	}
+
+	a.watchMu.Lock()
+	a.watcher = watcher
+	a.watchPath = path
+	a.watchDone = make(chan struct{})
+	done := a.watchDone
+	a.watchMu.Unlock()
+
+	a.pendingMu.Lock()
+	a.pendingPaths = make(map[string]string)
+	a.pendingMu.Unlock()
+
+	go a.watchLoop(watcher, done)
+
+	return nil
+}
+
+// StopWatching stops any active file watch. It is a no-op if nothing is
+// being watched.
+func (a *App) StopWatching() error {
+	a.watchMu.Lock()
+	defer a.watchMu.Unlock()
+
+	a.pendingMu.Lock()
+	if a.debounce != nil {
+		a.debounce.Stop()
+		a.debounce = nil
+	}
+	a.pendingPaths = nil
+	a.pendingMu.Unlock()
+
+	if a.watcher == nil {
+		return nil
+	}
+
+	close(a.watchDone)
+	err := a.watcher.Close()
+	a.watcher = nil
+	a.watchPath = ""
+	a.watchDone = nil
+	return err
+}
+
+func (a *App) watchLoop(watcher *fsnotify.Watcher, done chan struct{}) {
+	for {
+		select {
+		case <-done:
+			return
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&fsnotify.Create == fsnotify.Create {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					addWatchDirs(watcher, event.Name)
+				}
+			}
+			a.queueChange(event.Name, event.Op.String())
+		case _, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+// queueChange aggregates rapid-fire events for the same path so that a save
+// burst from an editor or build tool collapses into a single emitted event.
+// Each path keeps its own most recent op, so a burst touching several paths
+// doesn't report them all under whichever op happened to reset the timer.
+func (a *App) queueChange(path, op string) {
+	a.pendingMu.Lock()
+	if a.pendingPaths == nil {
+		a.pendingPaths = make(map[string]string)
+	}
+	a.pendingPaths[path] = op
+	if a.debounce != nil {
+		a.debounce.Stop()
+	}
+	a.debounce = time.AfterFunc(debounceWindow, a.flushPendingChanges)
+	a.pendingMu.Unlock()
+}
+
+func (a *App) flushPendingChanges() {
+	a.pendingMu.Lock()
+	paths := a.pendingPaths
+	a.pendingPaths = make(map[string]string)
+	a.pendingMu.Unlock()
+
+	if a.ctx == nil {
+		return
+	}
+	for path, op := range paths {
+		runtime.EventsEmit(a.ctx, "file:changed", FileChangeEvent{Path: path, Op: op})
+	}
+}
+
+// addWatchDirs adds root and all of its subdirectories to watcher.
+func addWatchDirs(watcher *fsnotify.Watcher, root string) error {
+	return filepath.Walk(root, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() {
+			return watcher.Add(p)
+		}
+		return nil
+	})
+}